@@ -1,22 +1,33 @@
 package main
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"context"
+	"encoding/csv"
+	encodingjson "encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
-	"k8s.io/client-go/discovery"
-	memory "k8s.io/client-go/discovery/cached"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery/cached/disk"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
@@ -25,53 +36,64 @@ import (
 
 // Supported CLI flags
 var (
-	help bool
-	namespace string
-	entry string
-	file string
-	outputFile string
+	help           bool
+	namespace      string
+	entry          string
+	file           string
+	outputFile     string
 	kubeConfigPath string
-	pure bool
-	depth int16
+	pure           bool
+	depth          int16
+	selector       string
+	fieldSelector  string
+	allNamespaces  bool
+	offline        bool
+	dumpPath       string
+	bundlePath     string
+	watchMode      bool
+	outputFormat   string
+	filterExpr     string
+	audit          bool
+	rulesFilePath  string
+	maxConcurrency int
 )
 
-
 // Resolve kubernetes resource identifier
 func resolveKind(input string) string {
 	switch input {
-    case "po", "pod", "pods":
-        return "pod"
-    case "svc", "service", "services":
-        return "service"
-    case "cm", "configmap", "configmaps":
-        return "configmap"
-    case "secret", "secrets":
-        return "secret"
-    case "ns", "namespace", "namespaces":
-        return "namespace"
-    case "no", "node", "nodes":
-        return "node"
-    case "ev", "event", "events":
-        return "event"
-    case "sa", "serviceaccount", "serviceaccounts":
-        return "serviceaccount"
-    case "ep", "endpoints":
-        return "endpoints"
-    case "sc", "storageclass", "storageclasses":
-        return "storageclass"
-    case "pv", "persistentvolume", "persistentvolumes":
-        return "persistentvolume"
-    case "pvc", "persistentvolumeclaim", "persistentvolumeclaims":
-        return "persistentvolumeclaim"
+	case "po", "pod", "pods":
+		return "pod"
+	case "svc", "service", "services":
+		return "service"
+	case "cm", "configmap", "configmaps":
+		return "configmap"
+	case "secret", "secrets":
+		return "secret"
+	case "ns", "namespace", "namespaces":
+		return "namespace"
+	case "no", "node", "nodes":
+		return "node"
+	case "ev", "event", "events":
+		return "event"
+	case "sa", "serviceaccount", "serviceaccounts":
+		return "serviceaccount"
+	case "ep", "endpoints":
+		return "endpoints"
+	case "sc", "storageclass", "storageclasses":
+		return "storageclass"
+	case "pv", "persistentvolume", "persistentvolumes":
+		return "persistentvolume"
+	case "pvc", "persistentvolumeclaim", "persistentvolumeclaims":
+		return "persistentvolumeclaim"
 
 	case "deploy", "deployment", "deployments":
 		return "deployment"
 	case "sts", "statefulset", "statefulsets":
 		return "statefulset"
-    case "ds", "daemonset", "daemonsets":
-        return "daemonset"
-    case "rs", "replicaset", "replicasets":
-        return "replicaset"
+	case "ds", "daemonset", "daemonsets":
+		return "daemonset"
+	case "rs", "replicaset", "replicasets":
+		return "replicaset"
 
 	case "job", "jobs":
 		return "job"
@@ -86,63 +108,86 @@ func resolveKind(input string) string {
 	case "eplice", "endpointsslice", "endpointslices":
 		return "endpointsslice"
 
-    case "role", "roles":
-        return "role"
-    case "rb", "rolebinding", "rolebindings":
-        return "rolebinding"
-    case "cr", "clusterrole", "clusterroles":
-        return "clusterrole"
-    case "crb", "clusterrolebinding", "clusterrolebindings":
-        return "clusterrolebinding"
+	case "role", "roles":
+		return "role"
+	case "rb", "rolebinding", "rolebindings":
+		return "rolebinding"
+	case "cr", "clusterrole", "clusterroles":
+		return "clusterrole"
+	case "crb", "clusterrolebinding", "clusterrolebindings":
+		return "clusterrolebinding"
 
-    case "hpa", "horizontalpodautoscaler", "horizontalpodautoscalers":
-        return "horizontalpodautoscaler"
+	case "hpa", "horizontalpodautoscaler", "horizontalpodautoscalers":
+		return "horizontalpodautoscaler"
 
 	default:
 		return input
 	}
 }
 
-// FetchDynamic retrieves any Kubernetes resource using its kind, namespace, and name.
-func FetchDynamicObject(
-	ctx context.Context,
-	restCfg *rest.Config,
-	kind, ns, name string,
-) (runtime.Object, error) {
+// clusterClients bundles the discovery-backed RESTMapper and dynamic client
+// for a single API server, built once per process (rather than per call) and
+// shared by every fetch/list/watch below. The discovery client persists its
+// responses under ~/.kube/cache/discovery, keyed by server URL, the same way
+// kubectl itself avoids re-discovering the API surface on every invocation.
+type clusterClients struct {
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+	dyn    dynamic.Interface
+}
+
+// newClusterClients builds the discovery/RESTMapper/dynamic client trio once
+// for restCfg's API server.
+func newClusterClients(restCfg *rest.Config) (*clusterClients, error) {
+	schemelessHost := strings.TrimPrefix(strings.TrimPrefix(restCfg.Host, "https://"), "http://")
+	safeHost := regexp.MustCompile(`[^\w/.]`).ReplaceAllString(schemelessHost, "_")
+	cacheDir := filepath.Join(os.Getenv("HOME"), ".kube", "cache", "discovery", safeHost)
 
-	// Create a discovery client (needed for API group + version discovery)
-	dc, err := discovery.NewDiscoveryClientForConfig(restCfg)
+	dc, err := disk.NewCachedDiscoveryClientForConfig(restCfg, cacheDir, "", 10*time.Minute)
 	if err != nil {
 		return nil, fmt.Errorf("error creating discovery client: %w", err)
 	}
 
-	// RESTMapper caches API discovery and resolves Kind ↔︎ GVR
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+	dyn, err := dynamic.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dynamic client: %w", err)
+	}
+
+	return &clusterClients{
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(dc),
+		dyn:    dyn,
+	}, nil
+}
 
-	gvk, err := mapper.KindFor(schema.GroupVersionResource{Resource: kind})
+// resourceFor resolves kind to its GVK/mapping and returns the matching
+// dynamic.ResourceInterface, namespaced unless the kind is cluster-scoped or
+// allNamespaces is set.
+func (c *clusterClients) resourceFor(kind, ns string, allNamespaces bool) (dynamic.ResourceInterface, schema.GroupVersionKind, error) {
+	gvk, err := c.mapper.KindFor(schema.GroupVersionResource{Resource: kind})
 	if err != nil {
-		return nil, fmt.Errorf("error resolving GVK for %s: %w", kind, err)
+		return nil, gvk, fmt.Errorf("error resolving GVK for %s: %w", kind, err)
 	}
 
-	// runtime-agnostic resource fetching
-	dyn, err := dynamic.NewForConfig(restCfg)
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return nil, fmt.Errorf("error creating dynamic client: %w", err)
+		return nil, gvk, err
 	}
 
-	// identify resource
-	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
-	if err != nil { return nil, err }
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && !allNamespaces {
+		return c.dyn.Resource(mapping.Resource).Namespace(ns), gvk, nil
+	}
+	return c.dyn.Resource(mapping.Resource), gvk, nil
+}
 
-	var resource dynamic.ResourceInterface
+// FetchDynamicObject retrieves any Kubernetes resource using its kind, namespace, and name.
+func FetchDynamicObject(
+	ctx context.Context,
+	clients *clusterClients,
+	kind, ns, name string,
+) (runtime.Object, error) {
 
-	// Handle scopped object
-	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
-		// namespaced resource
-		resource = dyn.Resource(mapping.Resource).Namespace(ns)
-	} else {
-		// cluster-scoped resource
-		resource = dyn.Resource(mapping.Resource)
+	resource, gvk, err := clients.resourceFor(kind, ns, false)
+	if err != nil {
+		return nil, err
 	}
 
 	// Fetch the object from Kubernetes
@@ -154,6 +199,253 @@ func FetchDynamicObject(
 	return obj, nil
 }
 
+// FetchDynamicObjectList lists every object of kind in ns (or across all
+// namespaces) matching the given label/field selectors, using the dynamic
+// client instead of Get. Mirrors the resolution steps of FetchDynamicObject.
+func FetchDynamicObjectList(
+	ctx context.Context,
+	clients *clusterClients,
+	kind, ns string,
+	allNamespaces bool,
+	labelSelector, fieldSelector string,
+) (*unstructured.UnstructuredList, error) {
+
+	resource, gvk, err := clients.resourceFor(kind, ns, allNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := resource.List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s (%s): %w", kind, gvk.String(), err)
+	}
+
+	return list, nil
+}
+
+// fetchManyAndEmit fetches each named object of kind concurrently (bounded by
+// maxConcurrency) and streams its walked/filtered/audited output through emit
+// as soon as it arrives, rather than waiting for the whole batch to land.
+func fetchManyAndEmit(
+	ctx context.Context,
+	clients *clusterClients,
+	kind, ns string,
+	names []string,
+	maxConcurrency int,
+	entryPath []string,
+	emit Emitter,
+	remain int,
+	filter string,
+) error {
+	// A non-positive limit would make errgroup's zero-capacity semaphore
+	// block every Go() call forever, hanging the command with no output.
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrency)
+
+	var emitMu sync.Mutex
+
+	for _, name := range names {
+		name := name
+		g.Go(func() error {
+			obj, err := FetchDynamicObject(ctx, clients, kind, ns, name)
+			if err != nil {
+				fmt.Println(err)
+				return nil
+			}
+
+			yamlBytes, err := serializeObject(obj)
+			if err != nil {
+				return fmt.Errorf("serialization error for %s/%s: %w", ns, name, err)
+			}
+
+			rootNode, err := parseDocumentRoot(yamlBytes)
+			if err != nil {
+				fmt.Println(err)
+				return nil
+			}
+
+			if entry != "" {
+				rootNode, err = findNodeByPath(rootNode, entry)
+				if err != nil {
+					fmt.Println(err)
+					return nil
+				}
+			}
+
+			emitMu.Lock()
+			defer emitMu.Unlock()
+			return processTree(rootNode, entryPath, emit, remain, filter)
+		})
+	}
+
+	return g.Wait()
+}
+
+// LocalSource reads resources from a directory tree previously dumped from a
+// cluster (support bundle, gitops repo, CI artifact, ...) instead of talking
+// to an API server. Files are indexed by convention: <dir>/<namespace>/<kind>/<name>.yaml,
+// with cluster-scoped kinds living directly under <dir>/<kind>/<name>.yaml.
+type LocalSource struct {
+	dir string
+}
+
+func NewLocalSource(dir string) *LocalSource {
+	return &LocalSource{dir: dir}
+}
+
+// Get reads the raw manifest bytes for kind/ns/name from the dump directory;
+// parsing them is left to the caller.
+func (s *LocalSource) Get(kind, ns, name string) ([]byte, error) {
+	kind = resolveKind(strings.ToLower(kind))
+
+	candidates := []string{
+		filepath.Join(s.dir, ns, kind, name+".yaml"),
+		filepath.Join(s.dir, ns, kind, name+".yml"),
+		filepath.Join(s.dir, ns, kind, name+".json"),
+		filepath.Join(s.dir, kind, name+".yaml"),
+		filepath.Join(s.dir, kind, name+".yml"),
+		filepath.Join(s.dir, kind, name+".json"),
+	}
+
+	for _, path := range candidates {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no dumped manifest found for %s/%s/%s under %s", ns, kind, name, s.dir)
+}
+
+// BundleSource reads resources straight out of a cluster support archive
+// (.zip or .tar.gz), without unpacking it to disk first. Entries are matched
+// by conventional path, e.g. cluster/<namespace>/<kind>/<name>.yaml.
+type BundleSource struct {
+	path string
+}
+
+func NewBundleSource(path string) *BundleSource {
+	return &BundleSource{path: path}
+}
+
+// clusterScopedKinds are the kinds resolveKind knows about that live outside
+// any namespace, mirroring resolveKind's own list. isManifestPath uses this
+// to decide whether a bare "<kind>/<name>" path is a legitimate match or
+// whether the namespace segment is required.
+var clusterScopedKinds = map[string]bool{
+	"namespace":          true,
+	"node":               true,
+	"storageclass":       true,
+	"persistentvolume":   true,
+	"clusterrole":        true,
+	"clusterrolebinding": true,
+}
+
+// isManifestPath reports whether an archive entry looks like the dumped
+// manifest for kind/ns/name, regardless of which top-level directory (e.g.
+// "cluster/") it was collected under. Namespaced kinds must match under the
+// namespace's own directory, so two same-named objects in different
+// namespaces don't collide; only cluster-scoped kinds fall back to a bare
+// "<kind>/<name>" path.
+func isManifestPath(entryPath, kind, ns, name string) bool {
+	entryPath = strings.ToLower(filepath.ToSlash(entryPath))
+	ext := filepath.Ext(entryPath)
+	if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		return false
+	}
+
+	base := strings.TrimSuffix(filepath.Base(entryPath), ext)
+	if base != name {
+		return false
+	}
+
+	dir := filepath.Dir(entryPath)
+	if clusterScopedKinds[kind] {
+		return dir == kind || strings.HasSuffix(dir, "/"+kind)
+	}
+	return dir == ns+"/"+kind || strings.HasSuffix(dir, "/"+ns+"/"+kind)
+}
+
+// Get scans the archive for the manifest matching kind/ns/name.
+func (s *BundleSource) Get(kind, ns, name string) ([]byte, error) {
+	kind = resolveKind(strings.ToLower(kind))
+
+	if strings.HasSuffix(s.path, ".zip") {
+		r, err := zip.OpenReader(s.path)
+		if err != nil {
+			return nil, fmt.Errorf("error opening bundle %s: %w", s.path, err)
+		}
+		defer r.Close()
+
+		for _, f := range r.File {
+			if !isManifestPath(f.Name, kind, ns, name) {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+
+		return nil, fmt.Errorf("no manifest for %s/%s/%s found in %s", ns, kind, name, s.path)
+	}
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening bundle %s: %w", s.path, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gzip stream in %s: %w", s.path, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !isManifestPath(hdr.Name, kind, ns, name) {
+			continue
+		}
+		return io.ReadAll(tr)
+	}
+
+	return nil, fmt.Errorf("no manifest for %s/%s/%s found in %s", ns, kind, name, s.path)
+}
+
+// parseDocumentRoot unmarshals YAML/JSON bytes into a node tree and returns
+// its root mapping node - the node findNodeByPath/getMapValue expect, not
+// the wrapping document node. Untrusted input - a dump directory or support
+// bundle someone handed you - can be empty, comment-only, or malformed, so
+// callers get an error instead of indexing into an empty Content slice.
+func parseDocumentRoot(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing manifest: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("manifest is empty or malformed")
+	}
+	return doc.Content[0], nil
+}
+
 func serializeObject(obj runtime.Object) ([]byte, error) {
 	scheme := runtime.NewScheme()
 	serializer := json.NewSerializerWithOptions(
@@ -174,7 +466,7 @@ func findNodeByPath(node *yaml.Node, entrypoint string) (*yaml.Node, error) {
 		if strings.Contains(part, "[") {
 			// extract name and the index between '[' and ']'
 			name := part[:strings.Index(part, "[")]
-			indexString := part[strings.Index(part, "[") + 1:strings.Index(part, "]")]
+			indexString := part[strings.Index(part, "[")+1 : strings.Index(part, "]")]
 			index, _ := strconv.Atoi(indexString)
 
 			// child object
@@ -194,10 +486,10 @@ func findNodeByPath(node *yaml.Node, entrypoint string) (*yaml.Node, error) {
 		}
 
 		// regular map key, no list
-        next := getMapValue(current, part)
-        if next == nil {
-            return nil, fmt.Errorf("invalid format: %s", entrypoint)
-        }
+		next := getMapValue(current, part)
+		if next == nil {
+			return nil, fmt.Errorf("invalid format: %s", entrypoint)
+		}
 
 		current = next
 	}
@@ -207,20 +499,20 @@ func findNodeByPath(node *yaml.Node, entrypoint string) (*yaml.Node, error) {
 
 // mapping node: get value for key
 func getMapValue(node *yaml.Node, key string) *yaml.Node {
-    if node.Kind != yaml.MappingNode {
-        return nil
-    }
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
 
 	// Content[0] = key1, Content[1] = value1
 	// Content[1] = key2, Content[1] = value2...
-    for i := 0; i < len(node.Content); i += 2 {
+	for i := 0; i < len(node.Content); i += 2 {
 		if node.Content[i].Value == key {
 			// Value for a given key
-            return node.Content[i+1]
-        }
-    }
+			return node.Content[i+1]
+		}
+	}
 
-    return nil
+	return nil
 }
 
 var autoGenerated = map[string]bool{
@@ -233,12 +525,210 @@ var autoGenerated = map[string]bool{
 	"status":            true, // skip whole status subtree
 }
 
-func walk(node *yaml.Node, path []string, out io.Writer, remain int) {
+// Record is a single flattened (path, value) pair produced by walk. Op is
+// only set by watch mode's diff output ("+"/"~"/"-" for added/changed/removed
+// paths); it's empty for a plain walk or audit record.
+type Record struct {
+	Path  string
+	Value string
+	Op    string `json:"op,omitempty"`
+}
+
+// Emitter receives flattened records one at a time and is responsible for
+// rendering them in a particular output format.
+type Emitter interface {
+	Emit(rec Record)
+	Flush() error
+}
+
+// cleanPath strips the "\b" merge trick walk uses to print array indices as
+// "path[0]" in flat text output; other formats want the plain dotted form.
+func cleanPath(path string) string {
+	return strings.ReplaceAll(path, ".\b", "")
+}
+
+// flatEmitter reproduces the original "path: value" text output. A watch-mode
+// diff record carries its "+"/"~"/"-" marker in Op instead of Path, so a
+// removed path (no Value) prints without the trailing ": ".
+type flatEmitter struct{ w io.Writer }
+
+func (e *flatEmitter) Emit(rec Record) {
+	if rec.Op == "-" {
+		fmt.Fprintf(e.w, "-%s\n", rec.Path)
+		return
+	}
+	fmt.Fprintf(e.w, "%s%s: %s\n", rec.Op, rec.Path, rec.Value)
+}
+func (e *flatEmitter) Flush() error { return nil }
+
+// jsonEmitter buffers every record and writes them as a single JSON array.
+type jsonEmitter struct {
+	w       io.Writer
+	records []Record
+}
+
+func (e *jsonEmitter) Emit(rec Record) {
+	rec.Path = cleanPath(rec.Path)
+	e.records = append(e.records, rec)
+}
+
+func (e *jsonEmitter) Flush() error {
+	enc := encodingjson.NewEncoder(e.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e.records)
+}
+
+// jsonlEmitter writes one JSON object per record, as it's emitted.
+type jsonlEmitter struct{ w io.Writer }
+
+func (e *jsonlEmitter) Emit(rec Record) {
+	rec.Path = cleanPath(rec.Path)
+	data, err := encodingjson.Marshal(rec)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(e.w, string(data))
+}
+
+func (e *jsonlEmitter) Flush() error { return nil }
+
+// delimitedEmitter backs both the csv and tsv output formats.
+type delimitedEmitter struct{ w *csv.Writer }
+
+func newDelimitedEmitter(w io.Writer, delimiter rune) *delimitedEmitter {
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+	return &delimitedEmitter{w: cw}
+}
+
+func (e *delimitedEmitter) Emit(rec Record) {
+	e.w.Write([]string{rec.Op + cleanPath(rec.Path), rec.Value})
+}
+
+func (e *delimitedEmitter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// newEmitter builds the Emitter for the requested --format.
+func newEmitter(format string, w io.Writer) (Emitter, error) {
+	switch format {
+	case "", "flat":
+		return &flatEmitter{w: w}, nil
+	case "json":
+		return &jsonEmitter{w: w}, nil
+	case "jsonl":
+		return &jsonlEmitter{w: w}, nil
+	case "csv":
+		return newDelimitedEmitter(w, ','), nil
+	case "tsv":
+		return newDelimitedEmitter(w, '\t'), nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want flat, json, jsonl, csv, or tsv)", format)
+	}
+}
+
+// filterEmitter forwards only records whose cleaned path matches re, for the
+// glob form of --filter. It lets filtering stay streaming rather than
+// buffering the whole tree.
+type filterEmitter struct {
+	inner Emitter
+	re    *regexp.Regexp
+}
+
+func (e *filterEmitter) Emit(rec Record) {
+	if e.re.MatchString(cleanPath(rec.Path)) {
+		e.inner.Emit(rec)
+	}
+}
+
+func (e *filterEmitter) Flush() error { return e.inner.Flush() }
+
+// collectingEmitter buffers every record, for filter forms that need the
+// whole record set at once (e.g. a jq-like predicate comparing siblings).
+type collectingEmitter struct{ records []Record }
+
+func (e *collectingEmitter) Emit(rec Record) { e.records = append(e.records, rec) }
+func (e *collectingEmitter) Flush() error    { return nil }
+
+// globToRegexp translates a --filter glob such as "spec.containers[*].image"
+// into a regexp matching a cleaned path, where "[*]" matches any array index
+// and "*" matches any run of characters within a segment.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "[*]"):
+			sb.WriteString(`\[\d+\]`)
+			i += 3
+		case pattern[i] == '*':
+			sb.WriteString(`[^.]*`)
+			i++
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			i++
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// isPredicateFilter reports whether expr uses the jq-like predicate form
+// (e.g. "spec.containers[?(@.image=~\"nginx.*\")].name") rather than a glob.
+func isPredicateFilter(expr string) bool {
+	return strings.Contains(expr, "[?(")
+}
+
+var predicatePattern = regexp.MustCompile(`^(.*)\[\?\(@\.([\w.]+)\s*=~\s*"([^"]*)"\)\](?:\.(.*))?$`)
+
+// filterByPredicate keeps records under array elements whose sibling field
+// matches the predicate's regex, e.g. keep every ".name" under a container
+// whose ".image" matches "nginx.*".
+func filterByPredicate(records []Record, expr string) ([]Record, error) {
+	m := predicatePattern.FindStringSubmatch(expr)
+	if m == nil {
+		return nil, fmt.Errorf("invalid filter predicate: %s", expr)
+	}
+	prefix, field, pattern, suffix := m[1], m[2], m[3], m[4]
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter regex %q: %w", pattern, err)
+	}
+
+	fieldPattern := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + `\[(\d+)\]\.` + regexp.QuoteMeta(field) + "$")
+	resultSuffix := ""
+	if suffix != "" {
+		resultSuffix = `\.` + regexp.QuoteMeta(suffix)
+	}
+	resultPattern := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + `\[(\d+)\]` + resultSuffix + `(?:[.\[].*)?$`)
+
+	passing := map[string]bool{}
+	for _, rec := range records {
+		if fm := fieldPattern.FindStringSubmatch(cleanPath(rec.Path)); fm != nil && re.MatchString(rec.Value) {
+			passing[fm[1]] = true
+		}
+	}
+
+	var out []Record
+	for _, rec := range records {
+		if rm := resultPattern.FindStringSubmatch(cleanPath(rec.Path)); rm != nil && passing[rm[1]] {
+			out = append(out, rec)
+		}
+	}
+
+	return out, nil
+}
+
+func walk(node *yaml.Node, path []string, emit Emitter, remain int) {
 	switch node.Kind {
 
 	case yaml.MappingNode: // YAML object
 		if remain == 0 {
-			fmt.Fprintf(out, "%s: <object>\n", strings.Join(path, "."))
+			emit.Emit(Record{Path: strings.Join(path, "."), Value: "<object>"})
 			return
 		}
 
@@ -256,12 +746,12 @@ func walk(node *yaml.Node, path []string, out io.Writer, remain int) {
 				continue
 			}
 
-			walk(valueNode, append(path, keyNode.Value), out, nextRem)
+			walk(valueNode, append(path, keyNode.Value), emit, nextRem)
 		}
 
 	case yaml.SequenceNode: // YAML list: arr[0], arr[1], ...
 		if remain == 0 {
-			fmt.Fprintf(out, "%s: <array>\n", strings.Join(path, "."))
+			emit.Emit(Record{Path: strings.Join(path, "."), Value: "<array>"})
 			return
 		}
 
@@ -271,12 +761,453 @@ func walk(node *yaml.Node, path []string, out io.Writer, remain int) {
 		}
 
 		for i, item := range node.Content {
-			walk(item, append(path, fmt.Sprintf("\b[%d]", i)), out, nextRem)
+			walk(item, append(path, fmt.Sprintf("\b[%d]", i)), emit, nextRem)
 		}
 
 	default: // reached a scaler value (tail)
-		fmt.Fprintf(out, "%s: %s\n", strings.Join(path, "."), node.Value)
+		emit.Emit(Record{Path: strings.Join(path, "."), Value: node.Value})
+	}
+}
+
+// walkFiltered runs walk over node and routes its records through out,
+// applying --filter along the way (streaming for a glob, buffered for a
+// predicate that needs to compare sibling fields).
+func walkFiltered(node *yaml.Node, path []string, out Emitter, remain int, filter string) error {
+	if filter == "" {
+		walk(node, path, out, remain)
+		return nil
+	}
+
+	if isPredicateFilter(filter) {
+		collector := &collectingEmitter{}
+		walk(node, path, collector, remain)
+
+		records, err := filterByPredicate(collector.records, filter)
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			out.Emit(rec)
+		}
+		return nil
+	}
+
+	re, err := globToRegexp(filter)
+	if err != nil {
+		return fmt.Errorf("invalid filter glob %q: %w", filter, err)
+	}
+	walk(node, path, &filterEmitter{inner: out, re: re}, remain)
+	return nil
+}
+
+// Finding is a single audit rule violation located in the flattened tree.
+type Finding struct {
+	RuleID   string
+	Severity string
+	Category string
+	Path     string
+	Message  string
+}
+
+// Rule is one audit check. Match inspects the parsed object tree and returns
+// a Finding for every violation it locates.
+type Rule struct {
+	ID       string
+	Severity string
+	Category string
+	Match    func(root *yaml.Node) []Finding
+}
+
+// getNestedMapValue walks a chain of map keys, returning nil as soon as any
+// key is missing instead of panicking on a nil node like getMapValue would.
+func getNestedMapValue(node *yaml.Node, keys ...string) *yaml.Node {
+	current := node
+	for _, key := range keys {
+		if current == nil {
+			return nil
+		}
+		current = getMapValue(current, key)
+	}
+	return current
+}
+
+// containerListPaths are the conventional locations of a pod template's
+// containers, covering bare Pods as well as Deployments/DaemonSets/etc.
+// whose pod spec lives under spec.template.
+var containerListPaths = []string{"spec.containers", "spec.template.spec.containers"}
+
+// findContainers locates the containers list in root and the dotted path
+// prefix it was found under, so findings can be reported at the right path.
+func findContainers(root *yaml.Node) (containers *yaml.Node, basePath string) {
+	for _, p := range containerListPaths {
+		if node, err := findNodeByPath(root, p); err == nil && node.Kind == yaml.SequenceNode {
+			return node, p
+		}
+	}
+	return nil, ""
+}
+
+// containerRule builds a Rule that flags every container for which fails
+// returns true, reporting the finding at basePath[i].reportSuffix.
+func containerRule(id, severity, category, reportSuffix, message string, fails func(container *yaml.Node) bool) Rule {
+	return Rule{
+		ID:       id,
+		Severity: severity,
+		Category: category,
+		Match: func(root *yaml.Node) []Finding {
+			containers, basePath := findContainers(root)
+			if containers == nil {
+				return nil
+			}
+
+			var findings []Finding
+			for i, container := range containers.Content {
+				if fails(container) {
+					findings = append(findings, Finding{
+						RuleID:   id,
+						Severity: severity,
+						Category: category,
+						Path:     fmt.Sprintf("%s[%d].%s", basePath, i, reportSuffix),
+						Message:  message,
+					})
+				}
+			}
+			return findings
+		},
+	}
+}
+
+// defaultRules is the built-in audit rule set for Pods/Deployments/DaemonSets.
+var defaultRules = []Rule{
+	containerRule("cpuLimitsMissing", "warning", "resources", "resources.limits.cpu",
+		"container does not set a CPU limit",
+		func(c *yaml.Node) bool { return getNestedMapValue(c, "resources", "limits", "cpu") == nil }),
+
+	containerRule("livenessProbeMissing", "warning", "reliability", "livenessProbe",
+		"container does not define a liveness probe",
+		func(c *yaml.Node) bool { return getNestedMapValue(c, "livenessProbe") == nil }),
+
+	containerRule("runningAsPrivileged", "critical", "security", "securityContext.privileged",
+		"container runs with securityContext.privileged: true",
+		func(c *yaml.Node) bool {
+			v := getNestedMapValue(c, "securityContext", "privileged")
+			return v != nil && v.Value == "true"
+		}),
+
+	containerRule("hostPortSet", "warning", "network", "ports",
+		"container binds a hostPort, exposing the node's network namespace",
+		func(c *yaml.Node) bool {
+			ports := getNestedMapValue(c, "ports")
+			if ports == nil || ports.Kind != yaml.SequenceNode {
+				return false
+			}
+			for _, p := range ports.Content {
+				if getMapValue(p, "hostPort") != nil {
+					return true
+				}
+			}
+			return false
+		}),
+}
+
+// userRule is the shape of one entry in a --rules-file: a path expression
+// plus an expected or forbidden value.
+type userRule struct {
+	ID       string `yaml:"id"`
+	Severity string `yaml:"severity"`
+	Category string `yaml:"category"`
+	Path     string `yaml:"path"`
+	Expect   string `yaml:"expect"`
+	Forbid   string `yaml:"forbid"`
+	Message  string `yaml:"message"`
+}
+
+func (u userRule) toRule() Rule {
+	return Rule{
+		ID:       u.ID,
+		Severity: u.Severity,
+		Category: u.Category,
+		Match: func(root *yaml.Node) []Finding {
+			node, err := findNodeByPath(root, u.Path)
+			if err != nil {
+				return nil
+			}
+
+			violated := (u.Expect != "" && node.Value != u.Expect) ||
+				(u.Forbid != "" && node.Value == u.Forbid)
+			if !violated {
+				return nil
+			}
+
+			return []Finding{{
+				RuleID:   u.ID,
+				Severity: u.Severity,
+				Category: u.Category,
+				Path:     u.Path,
+				Message:  u.Message,
+			}}
+		},
+	}
+}
+
+// rulesFile is the top-level document read from --rules-file.
+type rulesFile struct {
+	Rules []userRule `yaml:"rules"`
+}
+
+// loadRulesFile reads user-supplied audit rules from a YAML file.
+func loadRulesFile(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rules file %s: %w", path, err)
+	}
+
+	var parsed rulesFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("error parsing rules file %s: %w", path, err)
+	}
+
+	rules := make([]Rule, len(parsed.Rules))
+	for i, u := range parsed.Rules {
+		rules[i] = u.toRule()
+	}
+	return rules, nil
+}
+
+// runAudit evaluates every rule against root and returns all findings.
+func runAudit(root *yaml.Node, rules []Rule) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		findings = append(findings, rule.Match(root)...)
+	}
+	return findings
+}
+
+// processTree routes a parsed object through either the audit rule set or
+// the regular walk/filter pipeline, emitting through the same Emitter either way.
+func processTree(root *yaml.Node, path []string, emit Emitter, remain int, filter string) error {
+	if !audit {
+		return walkFiltered(root, path, emit, remain, filter)
+	}
+
+	rules := append([]Rule{}, defaultRules...)
+	if rulesFilePath != "" {
+		userRules, err := loadRulesFile(rulesFilePath)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, userRules...)
+	}
+
+	// A bare-kind audit (`kubectl walk pods --audit`) resolves to a List, so
+	// root is items[]+metadata rather than a single object; run the rules
+	// against each element and prefix findings with its position in the list.
+	if items := getMapValue(root, "items"); items != nil && items.Kind == yaml.SequenceNode {
+		for i, item := range items.Content {
+			for _, finding := range runAudit(item, rules) {
+				emit.Emit(Record{
+					Path:  fmt.Sprintf("items[%d].%s", i, finding.Path),
+					Value: fmt.Sprintf("%s: %s (%s/%s)", finding.Severity, finding.Message, finding.Category, finding.RuleID),
+				})
+			}
+		}
+		return nil
+	}
+
+	for _, finding := range runAudit(root, rules) {
+		emit.Emit(Record{
+			Path:  finding.Path,
+			Value: fmt.Sprintf("%s: %s (%s/%s)", finding.Severity, finding.Message, finding.Category, finding.RuleID),
+		})
+	}
+	return nil
+}
+
+// collectPaths walks node the same way walk does, but records "path: value"
+// entries into a map keyed by path instead of emitting them through an Emitter.
+// Used by watch mode to diff successive snapshots of an object.
+func collectPaths(node *yaml.Node, path []string, remain int, out map[string]string) {
+	switch node.Kind {
+
+	case yaml.MappingNode:
+		if remain == 0 {
+			out[strings.Join(path, ".")] = "<object>"
+			return
+		}
+
+		nextRem := remain
+		if remain > 0 {
+			nextRem = remain - 1
+		}
+
+		for i := 0; i < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			valueNode := node.Content[i+1]
+
+			if pure && autoGenerated[keyNode.Value] {
+				continue
+			}
+
+			collectPaths(valueNode, append(path, keyNode.Value), nextRem, out)
+		}
+
+	case yaml.SequenceNode:
+		if remain == 0 {
+			out[strings.Join(path, ".")] = "<array>"
+			return
+		}
+
+		nextRem := remain
+		if remain > 0 {
+			nextRem = remain - 1
+		}
+
+		for i, item := range node.Content {
+			collectPaths(item, append(path, fmt.Sprintf("\b[%d]", i)), nextRem, out)
+		}
+
+	default:
+		out[strings.Join(path, ".")] = node.Value
+	}
+}
+
+// diffToRecords compares the previously emitted path/value pairs for an
+// object against its latest snapshot and returns one Record per change: Op
+// "+" for a path that appeared, "~" for a path whose value changed (Value
+// holds "old -> new"), "-" for a path that disappeared.
+func diffToRecords(prev, cur map[string]string) []Record {
+	var out []Record
+	for path, value := range cur {
+		if oldValue, ok := prev[path]; !ok {
+			out = append(out, Record{Op: "+", Path: path, Value: value})
+		} else if oldValue != value {
+			out = append(out, Record{Op: "~", Path: path, Value: fmt.Sprintf("%s -> %s", oldValue, value)})
+		}
+	}
+
+	for path := range prev {
+		if _, ok := cur[path]; !ok {
+			out = append(out, Record{Op: "-", Path: path})
+		}
+	}
+
+	return out
+}
+
+// filterRecords applies --filter to an already-materialized record set, used
+// by watch mode where records come from a diff rather than a streamed walk.
+// Only the glob form is supported: a sibling-comparing predicate has no
+// well-defined meaning against a partial diff.
+func filterRecords(records []Record, filter string) ([]Record, error) {
+	if filter == "" {
+		return records, nil
+	}
+	if isPredicateFilter(filter) {
+		return nil, fmt.Errorf("predicate filters are not supported in watch mode; use a glob like spec.containers[*].image")
+	}
+
+	re, err := globToRegexp(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter glob %q: %w", filter, err)
+	}
+
+	var out []Record
+	for _, rec := range records {
+		if re.MatchString(cleanPath(rec.Path)) {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// watchResource watches the resolved GVR and emits an incremental diff of
+// flattened path/value pairs for every ADDED/MODIFIED/DELETED event, keyed
+// per-object by UID so concurrent objects don't clobber each other's state.
+// Diffs are routed through emit/filter like every other walk, so -F/--format and
+// --filter apply to watch output the same way they do to a one-shot get.
+func watchResource(
+	ctx context.Context,
+	clients *clusterClients,
+	kind, ns string,
+	allNamespaces bool,
+	labelSelector, fieldSelector string,
+	entryPath []string,
+	emit Emitter,
+	filter string,
+) error {
+
+	resource, gvk, err := clients.resourceFor(kind, ns, allNamespaces)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := resource.Watch(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+	})
+	if err != nil {
+		return fmt.Errorf("error watching %s (%s): %w", kind, gvk.String(), err)
+	}
+	defer watcher.Stop()
+
+	lastSeen := map[string]map[string]string{}
+
+	emitDiff := func(prev, cur map[string]string) error {
+		records, err := filterRecords(diffToRecords(prev, cur), filter)
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			emit.Emit(rec)
+		}
+		return emit.Flush()
+	}
+
+	for event := range watcher.ResultChan() {
+		obj, ok := event.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		uid := string(obj.GetUID())
+
+		if event.Type == watch.Deleted {
+			if err := emitDiff(lastSeen[uid], map[string]string{}); err != nil {
+				return err
+			}
+			delete(lastSeen, uid)
+			continue
+		}
+
+		yamlBytes, err := serializeObject(obj)
+		if err != nil {
+			fmt.Println("serialization error:", err)
+			continue
+		}
+
+		rootNode, err := parseDocumentRoot(yamlBytes)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		if entry != "" {
+			rootNode, err = findNodeByPath(rootNode, entry)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+		}
+
+		cur := map[string]string{}
+		collectPaths(rootNode, entryPath, int(depth), cur)
+
+		if err := emitDiff(lastSeen[uid], cur); err != nil {
+			return err
+		}
+		lastSeen[uid] = cur
 	}
+
+	return nil
 }
 
 func prepareCliFlags() {
@@ -284,10 +1215,22 @@ func prepareCliFlags() {
 	pflag.StringVarP(&namespace, "namespace", "n", "default", "Namespace of kind")
 	pflag.StringVarP(&entry, "entry", "e", "", "Entrypoint of an object")
 	pflag.StringVarP(&file, "file", "f", "", "YAML file to read regardless of kubernetes resource")
-	pflag.StringVarP(&outputFile, "output", "o", "", "Write inside file instead of stdin")
-	pflag.StringVarP(&kubeConfigPath, "kubeconfig", "c", os.Getenv("HOME") + "/.kube/config", "Cluster Kubeconfig file")
+	pflag.StringVarP(&outputFile, "output", "o", "", "Write inside file instead of stdout")
+	pflag.StringVarP(&outputFormat, "format", "F", "flat", "Output format: flat, json, jsonl, csv, tsv")
+	pflag.StringVar(&filterExpr, "filter", "", "Keep only paths matching a glob (spec.containers[*].image) or jq-like predicate (spec.containers[?(@.image=~\"nginx.*\")].name)")
+	pflag.BoolVar(&audit, "audit", false, "Evaluate the built-in lint rules against the resource instead of walking it")
+	pflag.StringVar(&rulesFilePath, "rules-file", "", "YAML file of additional audit rules to run alongside the built-in rule set")
+	pflag.IntVar(&maxConcurrency, "max-concurrency", 4, "Max parallel Get calls when walking multiple named resources; a list is always fetched with one List call")
+	pflag.StringVarP(&kubeConfigPath, "kubeconfig", "c", os.Getenv("HOME")+"/.kube/config", "Cluster Kubeconfig file")
 	pflag.BoolVarP(&pure, "pure", "p", false, "Strip auto-generated fields")
 	pflag.Int16VarP(&depth, "depth", "d", -1, "Depth of walking")
+	pflag.StringVarP(&selector, "selector", "l", "", "Label selector to filter a list of resources")
+	pflag.StringVar(&fieldSelector, "field-selector", "", "Field selector to filter a list of resources")
+	pflag.BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the requested resource across all namespaces")
+	pflag.BoolVar(&offline, "offline", false, "Walk resources from a local dump directory instead of a live cluster")
+	pflag.StringVar(&dumpPath, "dump-path", "", "Directory of dumped manifests to read when --offline is set")
+	pflag.StringVar(&bundlePath, "bundle", "", "Cluster support bundle (.zip or .tar.gz) to walk resources from")
+	pflag.BoolVarP(&watchMode, "watch", "w", false, "Watch the resolved resource(s) and print an incremental diff of changed paths")
 	pflag.Parse()
 }
 
@@ -296,13 +1239,13 @@ func printUsage() {
 	fmt.Println("$ kubectl walk pod nginx --entry spec.containers")
 	fmt.Print(
 		"spec.containers[0].image: nginx\n" +
-		"spec.containers[0].imagePullPolicy: Always\n" +
-		"spec.containers[0].name: nginx-pod\n" +
-		"spec.containers[0].terminationMessagePath: /dev/termination-log\n" +
-		"spec.containers[0].terminationMessagePolicy: File\n" +
-		"spec.containers[0].volumeMounts[0].mountPath: /var/run/secrets/kubernetes.io/serviceaccount\n" +
-		"spec.containers[0].volumeMounts[0].name: kube-api-access-vvbkx\n" +
-		"spec.containers[0].volumeMounts[0].readOnly: true\n")
+			"spec.containers[0].imagePullPolicy: Always\n" +
+			"spec.containers[0].name: nginx-pod\n" +
+			"spec.containers[0].terminationMessagePath: /dev/termination-log\n" +
+			"spec.containers[0].terminationMessagePolicy: File\n" +
+			"spec.containers[0].volumeMounts[0].mountPath: /var/run/secrets/kubernetes.io/serviceaccount\n" +
+			"spec.containers[0].volumeMounts[0].name: kube-api-access-vvbkx\n" +
+			"spec.containers[0].volumeMounts[0].readOnly: true\n")
 
 	fmt.Println("Usage:")
 	pflag.PrintDefaults()
@@ -325,7 +1268,7 @@ func main() {
 	var err error
 	out := os.Stdout
 
-	// Create a file if -o provided
+	// Create a file if -o/--output provided
 	if outputFile != "" {
 		out, err = os.Create(outputFile)
 		if err != nil {
@@ -335,8 +1278,11 @@ func main() {
 		defer out.Close()
 	}
 
-	// Parse YAML into yaml.Node tree
-	var yamlRoot yaml.Node
+	emit, err := newEmitter(outputFormat, out)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 
 	// Read from .yaml file
 	if file != "" {
@@ -346,9 +1292,62 @@ func main() {
 			return
 		}
 
-		yaml.Unmarshal(yamlBytes, &yamlRoot)
-		rootNode := yamlRoot.Content[0]
-		walk(rootNode, entryPath, out, int(depth))
+		rootNode, err := parseDocumentRoot(yamlBytes)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if err := processTree(rootNode, entryPath, emit, int(depth), filterExpr); err != nil {
+			fmt.Println(err)
+			return
+		}
+		emit.Flush()
+		return
+	}
+
+	// source is satisfied by any offline resource lookup: a dump
+	// directory (LocalSource) or a support archive (BundleSource).
+	var source interface {
+		Get(kind, ns, name string) ([]byte, error)
+	}
+
+	if offline {
+		source = NewLocalSource(dumpPath)
+	} else if bundlePath != "" {
+		source = NewBundleSource(bundlePath)
+	}
+
+	// Read from a local dump directory or support bundle instead of a live cluster
+	if source != nil {
+		args := pflag.Args()
+		kind := args[0]
+		name := strings.ToLower(args[1])
+
+		yamlBytes, err := source.Get(kind, namespace, name)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		rootNode, err := parseDocumentRoot(yamlBytes)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		if entry != "" {
+			rootNode, err = findNodeByPath(rootNode, entry)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+		}
+
+		if err := processTree(rootNode, entryPath, emit, int(depth), filterExpr); err != nil {
+			fmt.Println(err)
+			return
+		}
+		emit.Flush()
 		return
 	}
 
@@ -358,36 +1357,90 @@ func main() {
 		return
 	}
 
+	clients, err := newClusterClients(restConfig)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	args := pflag.Args()
 	kind := resolveKind(strings.ToLower(args[0]))
-	kindName := strings.ToLower(args[1])
 
-	// Read a Kubernetes resource
-	obj, err := FetchDynamicObject(context.TODO(), restConfig, kind, namespace, kindName)
-	if err != nil {
-		fmt.Println(err)
+	if watchMode {
+		// The "json" format buffers every record into one array on Flush,
+		// which never fires meaningfully on an open-ended watch stream.
+		if outputFormat == "json" {
+			fmt.Println(`--format json can't stream; use flat (default), jsonl, csv, or tsv with --watch`)
+			return
+		}
+		if err := watchResource(context.TODO(), clients, kind, namespace, allNamespaces, selector, fieldSelector, entryPath, emit, filterExpr); err != nil {
+			fmt.Println(err)
+		}
 		return
 	}
 
-	yamlBytes, err := serializeObject(obj)
-	if err != nil {
-		fmt.Println("serialization error: " + err.Error())
+	// A bare kind (no name) or an explicit selector/-A means "list", not "get".
+	listRequested := len(args) < 2 || selector != "" || fieldSelector != "" || allNamespaces
+
+	// Several names after the kind: fetch them all in parallel and stream
+	// output as each one arrives, rather than one Get at a time.
+	if !listRequested && len(args) > 2 {
+		names := make([]string, len(args)-1)
+		for i, name := range args[1:] {
+			names[i] = strings.ToLower(name)
+		}
+
+		if err := fetchManyAndEmit(context.TODO(), clients, kind, namespace, names, maxConcurrency, entryPath, emit, int(depth), filterExpr); err != nil {
+			fmt.Println(err)
+			return
+		}
+		emit.Flush()
 		return
 	}
 
-	yaml.Unmarshal(yamlBytes, &yamlRoot)
-	rootNode := yamlRoot.Content[0]
+	var obj runtime.Object
+
+	if listRequested {
+		list, err := FetchDynamicObjectList(context.TODO(), clients, kind, namespace, allNamespaces, selector, fieldSelector)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		obj = list
+	} else {
+		kindName := strings.ToLower(args[1])
+
+		// Read a Kubernetes resource
+		obj, err = FetchDynamicObject(context.TODO(), clients, kind, namespace, kindName)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
 
-	if entry == "" {
-		walk(rootNode, entryPath, out, int(depth))
+	yamlBytes, err := serializeObject(obj)
+	if err != nil {
+		fmt.Println("serialization error: " + err.Error())
 		return
 	}
 
-	rootNode, err = findNodeByPath(&yamlRoot, entry)
+	rootNode, err := parseDocumentRoot(yamlBytes)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
-	walk(rootNode, entryPath, out, int(depth))
+	if entry != "" {
+		rootNode, err = findNodeByPath(rootNode, entry)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	if err := processTree(rootNode, entryPath, emit, int(depth), filterExpr); err != nil {
+		fmt.Println(err)
+		return
+	}
+	emit.Flush()
 }